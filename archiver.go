@@ -0,0 +1,29 @@
+package unarch
+
+import "fmt"
+
+// Archiver writes new archives in one container format. Implementations
+// register themselves with RegisterArchiver from an init func, keyed by
+// the format name accepted by Create.
+type Archiver interface {
+	Create(dest string, sources []string, opts Options) error
+}
+
+var archivers = map[string]Archiver{}
+
+// RegisterArchiver adds a to the set of formats Create can build, under
+// name.
+func RegisterArchiver(name string, a Archiver) {
+	archivers[name] = a
+}
+
+// Create builds an archive at dest from sources (files and directories,
+// walked recursively) using the named format: "tar", "tar.gz", "tar.zst",
+// "tar.xz", or "zip".
+func Create(dest string, sources []string, format string) error {
+	a, ok := archivers[format]
+	if !ok {
+		return fmt.Errorf("unarch: unsupported archive format %q", format)
+	}
+	return a.Create(dest, sources, Options{})
+}
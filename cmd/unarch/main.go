@@ -0,0 +1,62 @@
+// Command unarch extracts an archive, auto-detecting its format. Passing
+// "-" as the archive reads a (possibly compressed) tar stream from stdin.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/TriangleGuy6644/unarch"
+)
+
+func main() {
+	listOnly := flag.Bool("list", false, "print entries instead of extracting them")
+	flag.BoolVar(listOnly, "t", false, "shorthand for -list")
+	stripComponents := flag.Int("strip-components", 0, "strip this many leading path components from each entry")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Println("usage: unarch [-list|-t] [-strip-components=N] <archive_file|-> [destination].")
+		os.Exit(1)
+	}
+	archivePath := flag.Arg(0)
+
+	if *listOnly {
+		if err := list(archivePath); err != nil {
+			fmt.Println("list error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	destDir := "."
+	if flag.NArg() >= 2 {
+		destDir = flag.Arg(1)
+	}
+	opts := unarch.Options{StripComponents: *stripComponents}
+
+	var err error
+	if archivePath == "-" {
+		err = unarch.ExtractStream(os.Stdin, destDir, opts)
+	} else {
+		err = unarch.Extract(archivePath, destDir, opts)
+	}
+	if err != nil {
+		fmt.Println("extraction error:", err)
+		os.Exit(1)
+	}
+	fmt.Println("extraction complete.")
+}
+
+func list(archivePath string) error {
+	entries, err := unarch.List(archivePath)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		fmt.Printf("%s\t%10d\t%s\t%s\n", e.Mode, e.Size, e.ModTime.Format(time.RFC3339), e.Name)
+	}
+	return nil
+}
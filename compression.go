@@ -0,0 +1,97 @@
+package unarch
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Compression identifies a stream-level compression codec, independent of
+// whatever container format (if any) it wraps.
+type Compression int
+
+const (
+	NoCompression Compression = iota
+	Gzip
+	Bzip2
+	Xz
+	Zstd
+	Lzip
+)
+
+func (c Compression) String() string {
+	switch c {
+	case Gzip:
+		return "gzip"
+	case Bzip2:
+		return "bzip2"
+	case Xz:
+		return "xz"
+	case Zstd:
+		return "zstd"
+	case Lzip:
+		return "lzip"
+	default:
+		return "none"
+	}
+}
+
+// DecompressStream peeks at r's leading bytes and returns a reader over
+// the decompressed payload together with the Compression it detected,
+// falling back to NoCompression if r isn't recognized as any of them.
+// Detection never seeks, so r may be a pipe, an HTTP response body, or
+// stdin, not just a regular file.
+func DecompressStream(r io.Reader) (io.ReadCloser, Compression, error) {
+	return decompressStream(r, 0)
+}
+
+// decompressStream is DecompressStream plus a zstdConcurrency knob: when
+// >1, a multi-frame zstd stream is decoded with that many worker
+// goroutines instead of the zstd package's default concurrency.
+func decompressStream(r io.Reader, zstdConcurrency int) (io.ReadCloser, Compression, error) {
+	br := bufio.NewReaderSize(r, 4096)
+	header, err := br.Peek(6)
+	if err != nil && err != io.EOF {
+		return nil, NoCompression, err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1F, 0x8B}):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, Gzip, err
+		}
+		return gr, Gzip, nil
+	case bytes.HasPrefix(header, []byte{0x42, 0x5A, 0x68}):
+		return io.NopCloser(bzip2.NewReader(br)), Bzip2, nil
+	case bytes.HasPrefix(header, []byte{0xFD, '7', 'z', 'X', 'Z', 0x00}):
+		xzr, err := xz.NewReader(br)
+		if err != nil {
+			return nil, Xz, err
+		}
+		return io.NopCloser(xzr), Xz, nil
+	case bytes.HasPrefix(header, []byte{0x28, 0xB5, 0x2F, 0xFD}):
+		var zopts []zstd.DOption
+		if zstdConcurrency > 1 {
+			zopts = append(zopts, zstd.WithDecoderConcurrency(zstdConcurrency))
+		}
+		zr, err := zstd.NewReader(br, zopts...)
+		if err != nil {
+			return nil, Zstd, err
+		}
+		return zstdReadCloser{zr}, Zstd, nil
+	case bytes.HasPrefix(header, lzipMagic):
+		lr, err := newLzipReader(br)
+		if err != nil {
+			return nil, Lzip, err
+		}
+		return io.NopCloser(lr), Lzip, nil
+	default:
+		return io.NopCloser(br), NoCompression, nil
+	}
+}
@@ -0,0 +1,55 @@
+package unarch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+func TestDecompressStreamDetectsGzip(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rc, comp, err := DecompressStream(&buf)
+	if err != nil {
+		t.Fatalf("DecompressStream: unexpected error: %v", err)
+	}
+	defer rc.Close()
+
+	if comp != Gzip {
+		t.Errorf("DecompressStream: got compression %v, want Gzip", comp)
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("DecompressStream: got payload %q, want %q", got, "hello")
+	}
+}
+
+func TestDecompressStreamPassesThroughUncompressed(t *testing.T) {
+	rc, comp, err := DecompressStream(bytes.NewReader([]byte("plain text")))
+	if err != nil {
+		t.Fatalf("DecompressStream: unexpected error: %v", err)
+	}
+	defer rc.Close()
+
+	if comp != NoCompression {
+		t.Errorf("DecompressStream: got compression %v, want NoCompression", comp)
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "plain text" {
+		t.Errorf("DecompressStream: got payload %q, want %q", got, "plain text")
+	}
+}
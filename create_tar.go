@@ -0,0 +1,94 @@
+package unarch
+
+import (
+	"archive/tar"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"compress/gzip"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// tarArchiver writes a tar archive, optionally wrapped in a compression
+// codec chosen by compress. A nil compress produces a bare tar.
+type tarArchiver struct {
+	compress func(io.Writer) (io.WriteCloser, error)
+}
+
+func (a tarArchiver) Create(dest string, sources []string, opts Options) error {
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w := io.Writer(out)
+	if a.compress != nil {
+		cw, err := a.compress(out)
+		if err != nil {
+			return err
+		}
+		defer cw.Close()
+		w = cw
+	}
+
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+
+	for _, src := range sources {
+		if err := addToTar(tw, src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addToTar(tw *tar.Writer, src string) error {
+	base := filepath.Dir(src)
+	return filepath.Walk(src, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+func init() {
+	RegisterArchiver("tar", tarArchiver{})
+	RegisterArchiver("tar.gz", tarArchiver{compress: func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	}})
+	RegisterArchiver("tar.zst", tarArchiver{compress: func(w io.Writer) (io.WriteCloser, error) {
+		return zstd.NewWriter(w)
+	}})
+	RegisterArchiver("tar.xz", tarArchiver{compress: func(w io.Writer) (io.WriteCloser, error) {
+		return xz.NewWriter(w)
+	}})
+}
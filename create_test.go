@@ -0,0 +1,43 @@
+package unarch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateAndExtractRoundTrip(t *testing.T) {
+	for _, format := range []string{"tar", "tar.gz", "tar.zst", "tar.xz", "zip"} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			srcDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(srcDir, "file.txt"), []byte("payload"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			archive := filepath.Join(t.TempDir(), "out."+format)
+			if err := Create(archive, []string{srcDir}, format); err != nil {
+				t.Fatalf("Create(%s): unexpected error: %v", format, err)
+			}
+
+			dest := t.TempDir()
+			if err := Extract(archive, dest, Options{}); err != nil {
+				t.Fatalf("Extract(%s): unexpected error: %v", format, err)
+			}
+
+			got, err := os.ReadFile(filepath.Join(dest, filepath.Base(srcDir), "file.txt"))
+			if err != nil {
+				t.Fatalf("Extract(%s): missing round-tripped file: %v", format, err)
+			}
+			if string(got) != "payload" {
+				t.Errorf("Extract(%s): got content %q, want %q", format, got, "payload")
+			}
+		})
+	}
+}
+
+func TestCreateUnsupportedFormat(t *testing.T) {
+	if err := Create(filepath.Join(t.TempDir(), "out.rar"), nil, "rar"); err == nil {
+		t.Error("Create: expected error for unsupported format, got nil")
+	}
+}
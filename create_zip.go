@@ -0,0 +1,69 @@
+package unarch
+
+import (
+	"archive/zip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type zipArchiver struct{}
+
+func (zipArchiver) Create(dest string, sources []string, opts Options) error {
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	defer zw.Close()
+
+	for _, src := range sources {
+		if err := addToZip(zw, src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addToZip(zw *zip.Writer, src string) error {
+	base := filepath.Dir(src)
+	return filepath.Walk(src, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+
+		if info.IsDir() {
+			_, err := zw.Create(name + "/")
+			return err
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Method = zip.Deflate
+
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	})
+}
+
+func init() { RegisterArchiver("zip", zipArchiver{}) }
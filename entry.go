@@ -0,0 +1,16 @@
+package unarch
+
+import (
+	"io/fs"
+	"time"
+)
+
+// Entry describes a single member of an archive, independent of the
+// underlying format.
+type Entry struct {
+	Name    string
+	Size    int64
+	Mode    fs.FileMode
+	ModTime time.Time
+	IsDir   bool
+}
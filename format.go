@@ -0,0 +1,69 @@
+package unarch
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format implements detection, extraction, and listing for one archive or
+// compression scheme. Implementations are wired into the registry by the
+// init below, which is the single place a new format needs to be added.
+type Format interface {
+	// Match reports whether header (the first bytes of the stream) or
+	// name (the source path, used for extension-based fallbacks)
+	// identify this format.
+	Match(header []byte, name string) bool
+
+	// Extract unpacks the archive read from r (size bytes long) into
+	// dest. name is the original archive path; most formats ignore it,
+	// but single-member compression schemes (e.g. a lone .gz file)
+	// derive their output filename from it.
+	Extract(r io.ReaderAt, size int64, name, dest string, opts Options) error
+
+	// List returns the archive's entries without writing anything to
+	// disk.
+	List(r io.ReaderAt, size int64, name string) ([]Entry, error)
+}
+
+var registry []Format
+
+// Register adds f to the set of formats consulted by Detect. Formats are
+// tried in registration order, so a more specific matcher (magic bytes)
+// should register ahead of a looser, extension-based fallback.
+func Register(f Format) {
+	registry = append(registry, f)
+}
+
+// Detect returns the first registered Format whose Match recognizes name
+// and the archive's leading bytes.
+func Detect(r io.ReaderAt, name string) (Format, error) {
+	header := make([]byte, 16)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	header = header[:n]
+
+	for _, f := range registry {
+		if f.Match(header, name) {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("unarch: unrecognized archive format for %q", name)
+}
+
+// init registers every built-in Format in explicit priority order. Order
+// matters: Detect returns the first Match, so magic-byte formats must come
+// before singleStreamFormat's extension-only fallback, or a compressed
+// tarball (tar.gz, tar.zst, ...) would be mistaken for a lone compressed
+// file and extracted as one undifferentiated blob instead of a tree.
+func init() {
+	Register(zipFormat{})
+	Register(tarFormat{})
+	Register(sevenZipFormat{})
+	Register(rarFormat{})
+	Register(lzipFormat{})
+	for _, ext := range []string{".gz", ".bz2", ".xz", ".zst"} {
+		Register(singleStreamFormat{ext: ext})
+	}
+}
@@ -0,0 +1,81 @@
+package unarch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ulikunitz/xz/lzma"
+)
+
+var lzipMagic = []byte("LZIP")
+
+// lzipFormat decodes the single-member .lz container (magic "LZIP")
+// produced by the lzip/plzip tools. Its payload is a headerless LZMA1
+// stream, so decoding reuses the lzma reader already vendored for xz
+// support instead of pulling in a dedicated lzip dependency.
+type lzipFormat struct{}
+
+func (lzipFormat) Match(header []byte, name string) bool {
+	return bytes.HasPrefix(header, lzipMagic)
+}
+
+func (lzipFormat) Extract(r io.ReaderAt, size int64, name, dest string, opts Options) error {
+	sr := io.NewSectionReader(r, 0, size)
+	lr, err := newLzipReader(sr)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+		return err
+	}
+	outPath := filepath.Join(dest, strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)))
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, lr)
+	return err
+}
+
+func (lzipFormat) List(r io.ReaderAt, size int64, name string) ([]Entry, error) {
+	return []Entry{{Name: strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))}}, nil
+}
+
+// newLzipReader checks an LZIP v1 header (magic, version), decodes its
+// coded dictionary size, and returns a reader over the decompressed
+// payload.
+func newLzipReader(r io.Reader) (io.Reader, error) {
+	header := make([]byte, 6)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:4], lzipMagic) {
+		return nil, fmt.Errorf("unarch: not an lzip stream")
+	}
+	if header[4] != 1 {
+		return nil, fmt.Errorf("unarch: unsupported lzip version %d", header[4])
+	}
+	dictCap := 1 << (header[5] & 0x1F)
+
+	// The lzma reader expects the classic 13-byte .lzma header, which lzip
+	// omits. lzip fixes the properties at lc=3, lp=0, pb=2 (byte 0x5D) and
+	// ends each member with an end-of-stream marker, so prepend a header
+	// with those properties and an unknown (all 0xFF) uncompressed size.
+	lzmaHeader := make([]byte, 13)
+	lzmaHeader[0] = 0x5D
+	binary.LittleEndian.PutUint32(lzmaHeader[1:5], uint32(dictCap))
+	for i := 5; i < len(lzmaHeader); i++ {
+		lzmaHeader[i] = 0xFF
+	}
+
+	cfg := lzma.ReaderConfig{DictCap: dictCap}
+	return cfg.NewReader(io.MultiReader(bytes.NewReader(lzmaHeader), r))
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package unarch
+
+import (
+	"archive/tar"
+	"fmt"
+)
+
+// createSpecialFile creates the device or FIFO node described by header
+// at target. Device and FIFO nodes have no portable representation
+// outside Unix, so this platform always reports an error.
+func createSpecialFile(target string, header *tar.Header) error {
+	return fmt.Errorf("unarch: device and fifo entries are not supported on this platform")
+}
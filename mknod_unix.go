@@ -0,0 +1,27 @@
+//go:build unix
+
+package unarch
+
+import (
+	"archive/tar"
+
+	"golang.org/x/sys/unix"
+)
+
+// createSpecialFile creates the device or FIFO node described by header
+// at target.
+func createSpecialFile(target string, header *tar.Header) error {
+	var mode uint32
+	switch header.Typeflag {
+	case tar.TypeChar:
+		mode = unix.S_IFCHR
+	case tar.TypeBlock:
+		mode = unix.S_IFBLK
+	case tar.TypeFifo:
+		mode = unix.S_IFIFO
+	}
+	mode |= uint32(header.Mode) & 0o7777
+
+	dev := unix.Mkdev(uint32(header.Devmajor), uint32(header.Devminor))
+	return unix.Mknod(target, mode, int(dev))
+}
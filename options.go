@@ -0,0 +1,35 @@
+package unarch
+
+import "archive/tar"
+
+// Options controls how an archive is extracted or listed. The zero value
+// selects sane defaults for every field.
+type Options struct {
+	// Concurrency bounds how many entries a format extracts at once.
+	// 0 or 1 extracts serially. Tar extraction (including
+	// ExtractStream) instead uses it, when >1, to size a multi-stream
+	// zstd decoder's worker pool. Rar and lzip ignore it.
+	Concurrency int
+
+	// PreserveOwners restores each tar entry's original uid/gid via
+	// os.Lchown. It only takes effect when running as root (euid 0);
+	// this mirrors Docker's TarOptions.NoLchown, inverted to default
+	// off instead of on.
+	PreserveOwners bool
+
+	// Filter, if set, is consulted for every tar entry before it's
+	// extracted; returning skip true omits the entry. It takes the raw
+	// *tar.Header rather than an unarch-specific type so callers can
+	// reuse logic written against archive/tar directly.
+	Filter func(header *tar.Header) (skip bool, err error)
+
+	// Includes, if non-empty, restricts tar extraction to entries whose
+	// (post StripComponents) name matches one of these filepath.Match
+	// glob patterns.
+	Includes []string
+
+	// StripComponents trims this many leading path components from
+	// each tar entry's name before extraction, like GNU tar's
+	// --strip-components. An entry left with no components is skipped.
+	StripComponents int
+}
@@ -0,0 +1,91 @@
+package unarch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sanitizeExtractPath joins name onto dest and guarantees the result stays
+// inside dest, rejecting absolute paths and any ".." traversal that would
+// otherwise let a crafted archive ("zip slip") write outside the
+// destination directory.
+func sanitizeExtractPath(name, dest string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("illegal file path %q: absolute paths are not allowed", name)
+	}
+
+	destAbs, err := filepath.Abs(dest)
+	if err != nil {
+		return "", err
+	}
+	destAbs = filepath.Clean(destAbs)
+
+	target := filepath.Join(destAbs, name)
+	if target != destAbs && !strings.HasPrefix(target, destAbs+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal file path %q: content filepath is outside the destination directory", name)
+	}
+	return target, nil
+}
+
+// sanitizeLinkTarget validates that a symlink/hardlink's target, resolved
+// relative to the directory containing entryName, still resolves inside
+// dest once extracted.
+func sanitizeLinkTarget(entryName, linkName, dest string) error {
+	destAbs, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	destAbs = filepath.Clean(destAbs)
+
+	var resolved string
+	if filepath.IsAbs(linkName) {
+		resolved = filepath.Clean(linkName)
+	} else {
+		resolved = filepath.Join(destAbs, filepath.Dir(entryName), linkName)
+	}
+	if resolved != destAbs && !strings.HasPrefix(resolved, destAbs+string(os.PathSeparator)) {
+		return fmt.Errorf("illegal link target %q for %q: escapes destination directory", linkName, entryName)
+	}
+	return nil
+}
+
+// mkdirAllNoFollow behaves like os.MkdirAll but refuses to create or
+// descend through a path component that is already a symlink, so a
+// malicious archive can't plant one ahead of time to redirect a later
+// entry's write outside dest.
+func mkdirAllNoFollow(path, dest string) error {
+	destAbs, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	destAbs = filepath.Clean(destAbs)
+
+	rel, err := filepath.Rel(destAbs, path)
+	if err != nil {
+		return err
+	}
+
+	cur := destAbs
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		if part == "" || part == "." {
+			continue
+		}
+		cur = filepath.Join(cur, part)
+		info, err := os.Lstat(cur)
+		if err == nil {
+			if info.Mode()&os.ModeSymlink != 0 {
+				return fmt.Errorf("refusing to follow existing symlink at %q", cur)
+			}
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.Mkdir(cur, os.ModePerm); err != nil && !os.IsExist(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package unarch
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSanitizeExtractPathRejectsTraversal(t *testing.T) {
+	dest := t.TempDir()
+
+	cases := []string{
+		"../../etc/passwd",
+		"a/../../b",
+		"/etc/passwd",
+	}
+	for _, name := range cases {
+		if _, err := sanitizeExtractPath(name, dest); err == nil {
+			t.Errorf("sanitizeExtractPath(%q, dest) = nil error, want rejection", name)
+		}
+	}
+}
+
+func TestSanitizeExtractPathAllowsNested(t *testing.T) {
+	dest := t.TempDir()
+
+	target, err := sanitizeExtractPath("a/b/c.txt", dest)
+	if err != nil {
+		t.Fatalf("sanitizeExtractPath: unexpected error: %v", err)
+	}
+	want := filepath.Join(dest, "a", "b", "c.txt")
+	if target != want {
+		t.Errorf("sanitizeExtractPath: got %q, want %q", target, want)
+	}
+}
+
+func TestSanitizeLinkTargetRejectsEscape(t *testing.T) {
+	dest := t.TempDir()
+
+	if err := sanitizeLinkTarget("link", "../../outside", dest); err == nil {
+		t.Error("sanitizeLinkTarget: expected rejection for escaping symlink target")
+	}
+	if err := sanitizeLinkTarget("dir/link", "../safe", dest); err != nil {
+		t.Errorf("sanitizeLinkTarget: unexpected error for in-bounds target: %v", err)
+	}
+}
+
+func TestExtractRejectsZipSlip(t *testing.T) {
+	dest := t.TempDir()
+	src := filepath.Join(t.TempDir(), "evil.zip")
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	fw, err := zw.Create("../../../tmp/unarch-zipslip-pwned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write([]byte("pwned")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Extract(src, dest, Options{}); err == nil {
+		t.Fatal("Extract: expected error for zip-slip archive, got nil")
+	}
+}
+
+func TestExtractRejectsTarTraversal(t *testing.T) {
+	dest := t.TempDir()
+	src := filepath.Join(t.TempDir(), "evil.tar")
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     "../../../tmp/unarch-untar-pwned",
+		Typeflag: tar.TypeReg,
+		Mode:     0o644,
+		Size:     5,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("pwned")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Extract(src, dest, Options{}); err == nil {
+		t.Fatal("Extract: expected error for malicious tar archive, got nil")
+	}
+}
+
+func TestExtractRejectsTarSymlinkEscape(t *testing.T) {
+	dest := t.TempDir()
+	src := filepath.Join(t.TempDir(), "evil-symlink.tar")
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     "escape",
+		Typeflag: tar.TypeSymlink,
+		Linkname: "../../../tmp",
+		Mode:     0o777,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Extract(src, dest, Options{}); err == nil {
+		t.Fatal("Extract: expected error for symlink escaping destination, got nil")
+	}
+}
@@ -0,0 +1,90 @@
+package unarch
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/nwaples/rardecode"
+)
+
+// rarFormat reads rar archives sequentially: unlike zip/7z, rardecode
+// exposes no random-access directory, so Extract and List both walk the
+// stream with Next/Read like archive/tar.
+type rarFormat struct{}
+
+func (rarFormat) Match(header []byte, name string) bool {
+	return bytes.HasPrefix(header, []byte{0x52, 0x61, 0x72, 0x21})
+}
+
+func (rarFormat) Extract(r io.ReaderAt, size int64, name, dest string, opts Options) error {
+	sr := io.NewSectionReader(r, 0, size)
+	rr, err := rardecode.NewReader(sr, "")
+	if err != nil {
+		return err
+	}
+
+	for {
+		header, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := sanitizeExtractPath(header.Name, dest)
+		if err != nil {
+			return err
+		}
+
+		if header.IsDir {
+			if err := mkdirAllNoFollow(target, dest); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := mkdirAllNoFollow(filepath.Dir(target), dest); err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, header.Mode())
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, rr)
+		out.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rarFormat) List(r io.ReaderAt, size int64, name string) ([]Entry, error) {
+	sr := io.NewSectionReader(r, 0, size)
+	rr, err := rardecode.NewReader(sr, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for {
+		header, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{
+			Name:    header.Name,
+			Size:    header.UnPackedSize,
+			Mode:    header.Mode(),
+			ModTime: header.ModificationTime,
+			IsDir:   header.IsDir,
+		})
+	}
+	return entries, nil
+}
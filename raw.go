@@ -0,0 +1,61 @@
+package unarch
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// singleStreamFormat writes a file named with a compression extension
+// (e.g. "notes.txt.gz") out as a single file. It matches purely on
+// extension, which is also true of a compressed tarball, so it must
+// register after tarFormat in format.go's init for tarFormat's magic-byte
+// Match to win first. Because tarFormat claims every gzip, bzip2, xz and
+// zstd stream by its magic bytes, a lone compressed file carrying that
+// magic is read as a tar; this format only sees files whose leading bytes
+// carry none of those magics.
+type singleStreamFormat struct {
+	ext string
+}
+
+func (f singleStreamFormat) Match(header []byte, name string) bool {
+	return filepath.Ext(name) == f.ext
+}
+
+func (f singleStreamFormat) Extract(r io.ReaderAt, size int64, name, dest string, opts Options) error {
+	sr := io.NewSectionReader(r, 0, size)
+	rc, _, err := DecompressStream(sr)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+		return err
+	}
+	outPath := filepath.Join(dest, strings.TrimSuffix(filepath.Base(name), f.ext))
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, rc)
+	return err
+}
+
+func (f singleStreamFormat) List(r io.ReaderAt, size int64, name string) ([]Entry, error) {
+	return []Entry{{Name: strings.TrimSuffix(filepath.Base(name), f.ext)}}, nil
+}
+
+// zstdReadCloser adapts *zstd.Decoder, whose Close returns no error, to
+// io.ReadCloser.
+type zstdReadCloser struct{ *zstd.Decoder }
+
+func (z zstdReadCloser) Close() error {
+	z.Decoder.Close()
+	return nil
+}
@@ -0,0 +1,107 @@
+package unarch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bodgit/sevenzip"
+	"golang.org/x/sync/errgroup"
+)
+
+type sevenZipFormat struct{}
+
+func (sevenZipFormat) Match(header []byte, name string) bool {
+	return bytes.HasPrefix(header, []byte{0x37, 0x7A, 0xBC, 0xAF, 0x27, 0x1C})
+}
+
+func (sevenZipFormat) Extract(r io.ReaderAt, size int64, name, dest string, opts Options) error {
+	zr, err := sevenzip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	// As in zipFormat, pre-create every directory entry serially before
+	// the file pass so concurrent writers never race on a shared parent.
+	var files []*sevenzip.File
+	for _, f := range zr.File {
+		target, err := sanitizeExtractPath(f.Name, dest)
+		if err != nil {
+			return err
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := mkdirAllNoFollow(target, dest); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := mkdirAllNoFollow(filepath.Dir(target), dest); err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+
+	if opts.Concurrency <= 1 {
+		for _, f := range files {
+			if err := extractSevenZipFile(f, dest); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(opts.Concurrency)
+	for _, f := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		f := f
+		g.Go(func() error { return extractSevenZipFile(f, dest) })
+	}
+	return g.Wait()
+}
+
+func extractSevenZipFile(f *sevenzip.File, dest string) error {
+	target, err := sanitizeExtractPath(f.Name, dest)
+	if err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+	if err != nil {
+		rc.Close()
+		return err
+	}
+	_, err = io.Copy(out, rc)
+	out.Close()
+	rc.Close()
+	return err
+}
+
+func (sevenZipFormat) List(r io.ReaderAt, size int64, name string) ([]Entry, error) {
+	zr, err := sevenzip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(zr.File))
+	for _, f := range zr.File {
+		entries = append(entries, Entry{
+			Name:    f.Name,
+			Size:    int64(f.UncompressedSize),
+			Mode:    f.Mode(),
+			ModTime: f.Modified,
+			IsDir:   f.FileInfo().IsDir(),
+		})
+	}
+	return entries, nil
+}
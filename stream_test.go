@@ -0,0 +1,51 @@
+package unarch
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractStreamFromPipe(t *testing.T) {
+	dest := t.TempDir()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     "hello.txt",
+		Typeflag: tar.TypeReg,
+		Mode:     0o644,
+		Size:     5,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		pw.Write(buf.Bytes())
+		pw.Close()
+	}()
+
+	if err := ExtractStream(pr, dest, Options{}); err != nil {
+		t.Fatalf("ExtractStream: unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dest, "hello.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "world" {
+		t.Errorf("ExtractStream: got content %q, want %q", got, "world")
+	}
+}
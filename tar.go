@@ -0,0 +1,208 @@
+package unarch
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type tarFormat struct{}
+
+func (tarFormat) Match(header []byte, name string) bool {
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1F, 0x8B}): // gzip
+		return true
+	case bytes.HasPrefix(header, []byte{0x42, 0x5A, 0x68}): // bzip2
+		return true
+	case bytes.HasPrefix(header, []byte{0xFD, '7', 'z', 'X', 'Z', 0x00}): // xz
+		return true
+	case bytes.HasPrefix(header, []byte{0x28, 0xB5, 0x2F, 0xFD}): // zstd
+		return true
+	}
+	// A bare tar has no magic in its first 16 bytes (the "ustar" magic
+	// sits at offset 257), so fall back on the extension.
+	return strings.HasSuffix(name, ".tar")
+}
+
+func (tarFormat) Extract(r io.ReaderAt, size int64, name, dest string, opts Options) error {
+	sr := io.NewSectionReader(r, 0, size)
+	dr, _, err := decompressStream(sr, opts.Concurrency)
+	if err != nil {
+		return err
+	}
+	defer dr.Close()
+
+	return extractTarEntries(tar.NewReader(dr), dest, opts)
+}
+
+func (tarFormat) List(r io.ReaderAt, size int64, name string) ([]Entry, error) {
+	sr := io.NewSectionReader(r, 0, size)
+	dr, _, err := DecompressStream(sr)
+	if err != nil {
+		return nil, err
+	}
+	defer dr.Close()
+
+	var entries []Entry
+	tr := tar.NewReader(dr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{
+			Name:    header.Name,
+			Size:    header.Size,
+			Mode:    os.FileMode(header.Mode),
+			ModTime: header.ModTime,
+			IsDir:   header.Typeflag == tar.TypeDir,
+		})
+	}
+	return entries, nil
+}
+
+// extractTarEntries writes every entry read from tr into dest, honoring
+// opts' Filter, Includes, and StripComponents. It takes a plain
+// *tar.Reader rather than a Format's (io.ReaderAt, size) pair so
+// ExtractStream can drive it directly from a non-seekable source such as
+// stdin or an HTTP response body.
+func extractTarEntries(tr *tar.Reader, dest string, opts Options) error {
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name, ok := stripPathComponents(header.Name, opts.StripComponents)
+		if !ok {
+			continue
+		}
+		header.Name = name
+
+		if !matchesIncludes(header.Name, opts.Includes) {
+			continue
+		}
+		if opts.Filter != nil {
+			skip, err := opts.Filter(header)
+			if err != nil {
+				return err
+			}
+			if skip {
+				continue
+			}
+		}
+
+		target, err := sanitizeExtractPath(header.Name, dest)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := mkdirAllNoFollow(target, dest); err != nil {
+				return err
+			}
+			if err := os.Chmod(target, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := mkdirAllNoFollow(filepath.Dir(target), dest); err != nil {
+				return err
+			}
+			outFile, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(outFile, tr)
+			outFile.Close()
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := sanitizeLinkTarget(header.Name, header.Linkname, dest); err != nil {
+				return err
+			}
+			if err := mkdirAllNoFollow(filepath.Dir(target), dest); err != nil {
+				return err
+			}
+			os.Remove(target)
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			linkname := header.Linkname
+			if stripped, ok := stripPathComponents(linkname, opts.StripComponents); ok {
+				linkname = stripped
+			}
+			linkTarget, err := sanitizeExtractPath(linkname, dest)
+			if err != nil {
+				return err
+			}
+			if err := mkdirAllNoFollow(filepath.Dir(target), dest); err != nil {
+				return err
+			}
+			os.Remove(target)
+			if err := os.Link(linkTarget, target); err != nil {
+				return err
+			}
+		case tar.TypeChar, tar.TypeBlock, tar.TypeFifo:
+			if err := mkdirAllNoFollow(filepath.Dir(target), dest); err != nil {
+				return err
+			}
+			os.Remove(target)
+			if err := createSpecialFile(target, header); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+
+		if opts.PreserveOwners && os.Geteuid() == 0 {
+			if err := os.Lchown(target, header.Uid, header.Gid); err != nil {
+				return err
+			}
+		}
+		if header.Typeflag != tar.TypeSymlink {
+			os.Chtimes(target, header.AccessTime, header.ModTime)
+		}
+	}
+	return nil
+}
+
+// stripPathComponents trims n leading "/"-separated components from name,
+// reporting ok=false when that leaves nothing (the entry should be
+// skipped), mirroring GNU tar's --strip-components.
+func stripPathComponents(name string, n int) (stripped string, ok bool) {
+	if n <= 0 {
+		return name, true
+	}
+	parts := strings.Split(name, "/")
+	if len(parts) <= n {
+		return "", false
+	}
+	stripped = strings.Join(parts[n:], "/")
+	return stripped, stripped != ""
+}
+
+// matchesIncludes reports whether name matches one of the glob patterns,
+// or whether patterns is empty (meaning "include everything").
+func matchesIncludes(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
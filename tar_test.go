@@ -0,0 +1,148 @@
+package unarch
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func buildTar(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, body := range entries {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0o644,
+			Size:     int64(len(body)),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractStripComponents(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "nested.tar")
+	data := buildTar(t, map[string]string{"pkg/sub/file.txt": "hi"})
+	if err := os.WriteFile(src, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := t.TempDir()
+	if err := Extract(src, dest, Options{StripComponents: 2}); err != nil {
+		t.Fatalf("Extract: unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dest, "file.txt"))
+	if err != nil {
+		t.Fatalf("Extract: strip-components didn't leave the expected file: %v", err)
+	}
+	if string(got) != "hi" {
+		t.Errorf("Extract: got %q, want %q", got, "hi")
+	}
+}
+
+func TestExtractIncludesFiltersEntries(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "mixed.tar")
+	data := buildTar(t, map[string]string{
+		"keep.txt": "keep",
+		"drop.log": "drop",
+		"also.txt": "also",
+	})
+	if err := os.WriteFile(src, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := t.TempDir()
+	if err := Extract(src, dest, Options{Includes: []string{"*.txt"}}); err != nil {
+		t.Fatalf("Extract: unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dest, "drop.log")); !os.IsNotExist(err) {
+		t.Errorf("Extract: expected drop.log to be excluded, stat err = %v", err)
+	}
+	for _, name := range []string{"keep.txt", "also.txt"} {
+		if _, err := os.Stat(filepath.Join(dest, name)); err != nil {
+			t.Errorf("Extract: expected %s to be extracted: %v", name, err)
+		}
+	}
+}
+
+func TestExtractStripComponentsHardlink(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "hardlink.tar")
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     "pkg/file.txt",
+		Typeflag: tar.TypeReg,
+		Mode:     0o644,
+		Size:     2,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("hi")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     "pkg/link.txt",
+		Typeflag: tar.TypeLink,
+		Linkname: "pkg/file.txt",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := t.TempDir()
+	if err := Extract(src, dest, Options{StripComponents: 1}); err != nil {
+		t.Fatalf("Extract: unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dest, "link.txt"))
+	if err != nil {
+		t.Fatalf("Extract: hardlink didn't resolve against the stripped path: %v", err)
+	}
+	if string(got) != "hi" {
+		t.Errorf("Extract: got %q, want %q", got, "hi")
+	}
+}
+
+func TestExtractFilterSkip(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "filtered.tar")
+	data := buildTar(t, map[string]string{"a.txt": "a", "b.txt": "b"})
+	if err := os.WriteFile(src, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := t.TempDir()
+	opts := Options{
+		Filter: func(header *tar.Header) (bool, error) {
+			return header.Name == "b.txt", nil
+		},
+	}
+	if err := Extract(src, dest, opts); err != nil {
+		t.Fatalf("Extract: unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dest, "b.txt")); !os.IsNotExist(err) {
+		t.Errorf("Extract: expected b.txt to be filtered out, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "a.txt")); err != nil {
+		t.Errorf("Extract: expected a.txt to be extracted: %v", err)
+	}
+}
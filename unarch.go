@@ -0,0 +1,66 @@
+// Package unarch detects, extracts, and lists archives (zip, tar and its
+// compressed variants, 7z, rar, and lzip) behind a single Format registry.
+// The cmd/unarch binary is a thin CLI wrapper around this package.
+package unarch
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+)
+
+// Extract detects src's archive format and unpacks it into dest.
+func Extract(src, dest string, opts Options) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	format, err := Detect(f, src)
+	if err != nil {
+		return err
+	}
+	return format.Extract(f, info.Size(), src, dest, opts)
+}
+
+// List detects src's archive format and returns its entries without
+// writing anything to disk.
+func List(src string) ([]Entry, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := Detect(f, src)
+	if err != nil {
+		return nil, err
+	}
+	return format.List(f, info.Size(), src)
+}
+
+// ExtractStream extracts a (possibly compressed) tar read from r into
+// dest. Unlike Extract, r need only be an io.Reader, so it can be stdin,
+// an HTTP response body, or any other pipe that doesn't support seeking
+// or random access. Formats that require random access, such as zip and
+// 7z, aren't available through this path.
+func ExtractStream(r io.Reader, dest string, opts Options) error {
+	dr, _, err := decompressStream(r, opts.Concurrency)
+	if err != nil {
+		return err
+	}
+	defer dr.Close()
+
+	return extractTarEntries(tar.NewReader(dr), dest, opts)
+}
@@ -0,0 +1,126 @@
+package unarch
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type zipFormat struct{}
+
+func (zipFormat) Match(header []byte, name string) bool {
+	return bytes.HasPrefix(header, []byte("PK"))
+}
+
+func (zipFormat) Extract(r io.ReaderAt, size int64, name, dest string, opts Options) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	// Pre-create every directory entry in a serial pass first, so the
+	// file pass below can run its entries in parallel without racing
+	// on shared parent directories.
+	var files []*zip.File
+	for _, f := range zr.File {
+		target, err := sanitizeExtractPath(f.Name, dest)
+		if err != nil {
+			return err
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := mkdirAllNoFollow(target, dest); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := mkdirAllNoFollow(filepath.Dir(target), dest); err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+
+	if opts.Concurrency <= 1 {
+		for _, f := range files {
+			if err := extractZipFile(f, dest); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(opts.Concurrency)
+	for _, f := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		f := f
+		g.Go(func() error { return extractZipFile(f, dest) })
+	}
+	return g.Wait()
+}
+
+// extractZipFile writes a single non-directory zip entry to dest. Each
+// caller opens its own section reader via f.Open, so this is safe to run
+// from multiple goroutines concurrently as long as dest's directory tree
+// already exists.
+func extractZipFile(f *zip.File, dest string) error {
+	target, err := sanitizeExtractPath(f.Name, dest)
+	if err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+
+	if f.Mode()&os.ModeSymlink != 0 {
+		linkTarget, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		if err := sanitizeLinkTarget(f.Name, string(linkTarget), dest); err != nil {
+			return err
+		}
+		os.Remove(target)
+		return os.Symlink(string(linkTarget), target)
+	}
+
+	outFile, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+	if err != nil {
+		rc.Close()
+		return err
+	}
+	_, err = io.Copy(outFile, rc)
+	outFile.Close()
+	rc.Close()
+	return err
+}
+
+func (zipFormat) List(r io.ReaderAt, size int64, name string) ([]Entry, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(zr.File))
+	for _, f := range zr.File {
+		entries = append(entries, Entry{
+			Name:    f.Name,
+			Size:    int64(f.UncompressedSize64),
+			Mode:    f.Mode(),
+			ModTime: f.Modified,
+			IsDir:   f.FileInfo().IsDir(),
+		})
+	}
+	return entries, nil
+}
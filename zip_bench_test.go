@@ -0,0 +1,59 @@
+package unarch
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func buildBenchZip(b *testing.B, fileCount int) []byte {
+	b.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	payload := bytes.Repeat([]byte("x"), 64*1024)
+	for i := 0; i < fileCount; i++ {
+		fw, err := zw.Create(fmt.Sprintf("file-%04d.bin", i))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := fw.Write(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		b.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// BenchmarkZipExtractSerialVsParallel compares serial extraction
+// (Concurrency: 0) against a worker pool (Concurrency: 8) over a
+// multi-file zip.
+func BenchmarkZipExtractSerialVsParallel(b *testing.B) {
+	data := buildBenchZip(b, 256)
+	src := filepath.Join(b.TempDir(), "bench.zip")
+	if err := os.WriteFile(src, data, 0o644); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, opts := range []struct {
+		name string
+		opts Options
+	}{
+		{"Serial", Options{}},
+		{"Parallel8", Options{Concurrency: 8}},
+	} {
+		opts := opts
+		b.Run(opts.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dest := b.TempDir()
+				if err := Extract(src, dest, opts.opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package unarch
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestZipExtractParallelMatchesSerial(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i < 16; i++ {
+		fw, err := zw.Create(fmt.Sprintf("dir/file-%02d.txt", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fmt.Fprintf(fw, "contents %d", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "fan-out.zip")
+	if err := os.WriteFile(src, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	destSerial := t.TempDir()
+	if err := Extract(src, destSerial, Options{}); err != nil {
+		t.Fatalf("Extract(serial): unexpected error: %v", err)
+	}
+
+	destParallel := t.TempDir()
+	if err := Extract(src, destParallel, Options{Concurrency: 4}); err != nil {
+		t.Fatalf("Extract(parallel): unexpected error: %v", err)
+	}
+
+	for i := 0; i < 16; i++ {
+		name := fmt.Sprintf("dir/file-%02d.txt", i)
+		want := fmt.Sprintf("contents %d", i)
+
+		got, err := os.ReadFile(filepath.Join(destParallel, name))
+		if err != nil {
+			t.Fatalf("parallel extract: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("parallel extract %s: got %q, want %q", name, got, want)
+		}
+
+		serial, err := os.ReadFile(filepath.Join(destSerial, name))
+		if err != nil {
+			t.Fatalf("serial extract: %v", err)
+		}
+		if string(got) != string(serial) {
+			t.Errorf("extract %s: parallel got %q, serial got %q", name, got, serial)
+		}
+	}
+}